@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/thalesfsp/openai-assistant/internal/assistant"
+	"github.com/thalesfsp/openai-assistant/store"
+)
+
+////////////////////
+// Utilities.
+////////////////////
+
+// extractStreamFlag removes the `--stream` flag from args, wherever it
+// appears, and reports whether it was present.
+func extractStreamFlag(args []string) ([]string, bool) {
+	remaining := make([]string, 0, len(args))
+	stream := false
+
+	for _, arg := range args {
+		if arg == "--stream" {
+			stream = true
+
+			continue
+		}
+
+		remaining = append(remaining, arg)
+	}
+
+	return remaining, stream
+}
+
+// extractAgentFlag removes `-a`/`--agent <name>` from args, wherever it
+// appears, and returns the selected agent name (empty if not present).
+func extractAgentFlag(args []string) ([]string, string) {
+	remaining := make([]string, 0, len(args))
+	name := ""
+
+	for i := 0; i < len(args); i++ {
+		if (args[i] == "-a" || args[i] == "--agent") && i+1 < len(args) {
+			name = args[i+1]
+			i++
+
+			continue
+		}
+
+		remaining = append(remaining, args[i])
+	}
+
+	return remaining, name
+}
+
+// extractConversationFlag removes `--conversation <name>` from args,
+// wherever it appears, and returns the selected conversation name (empty if
+// not present).
+func extractConversationFlag(args []string) ([]string, string) {
+	remaining := make([]string, 0, len(args))
+	name := ""
+
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--conversation" && i+1 < len(args) {
+			name = args[i+1]
+			i++
+
+			continue
+		}
+
+		remaining = append(remaining, args[i])
+	}
+
+	return remaining, name
+}
+
+// isStoreSubcommand reports whether `name` is one of the store-only
+// subcommands (`list`, `show`, `delete`).
+func isStoreSubcommand(name string) bool {
+	switch name {
+	case "list", "show", "delete":
+		return true
+	default:
+		return false
+	}
+}
+
+// runStoreSubcommand dispatches `list`, `show <name>`, and `delete <name>`,
+// which operate purely on the local store.
+func runStoreSubcommand(ctx context.Context, ts store.ThreadStore, args []string) {
+	switch args[0] {
+	case "list":
+		threads, err := ts.ListThreads(ctx)
+		if err != nil {
+			panic("ListThreads: " + err.Error())
+		}
+
+		printJSON(threads)
+	case "show":
+		if len(args) < 2 {
+			panic("show requires a conversation name")
+		}
+
+		thread, err := ts.LoadThread(ctx, args[1])
+		if err != nil {
+			panic("LoadThread: " + err.Error())
+		}
+
+		printJSON(thread)
+	case "delete":
+		if len(args) < 2 {
+			panic("delete requires a conversation name")
+		}
+
+		if err := ts.DeleteThread(ctx, args[1]); err != nil {
+			panic("DeleteThread: " + err.Error())
+		}
+
+		fmt.Println("ok")
+	}
+}
+
+// isFineTuneCommand reports whether `name` is the top-level `finetune`
+// command, which fans out to its own subcommands in
+// `runFineTuneSubcommand`.
+func isFineTuneCommand(name string) bool {
+	return name == "finetune"
+}
+
+// runFineTuneSubcommand dispatches the `finetune` subcommands: `create`,
+// `list`, `get`, `cancel`, `events`, and `register`.
+func runFineTuneSubcommand(
+	ctx context.Context,
+	client *openai.Client,
+	fs store.FineTuningStore,
+	args []string,
+) {
+	if len(args) == 0 {
+		panic("finetune requires a subcommand: create, list, get, cancel, events, register")
+	}
+
+	switch args[0] {
+	case "create":
+		if len(args) < 2 {
+			panic("finetune create requires a training file path")
+		}
+
+		var model, suffix string
+
+		if len(args) >= 3 {
+			model = args[2]
+		}
+
+		if len(args) >= 4 {
+			suffix = args[3]
+		}
+
+		job, err := assistant.CreateFineTuningJob(ctx, client, fs, args[1], model, suffix)
+		if err != nil {
+			panic("CreateFineTuningJob: " + err.Error())
+		}
+
+		printJSON(job)
+	case "list":
+		jobs, err := fs.ListFineTuningJobs(ctx)
+		if err != nil {
+			panic("ListFineTuningJobs: " + err.Error())
+		}
+
+		printJSON(jobs)
+	case "get":
+		if len(args) < 2 {
+			panic("finetune get requires a job ID")
+		}
+
+		job, err := client.RetrieveFineTuningJob(ctx, args[1])
+		if err != nil {
+			panic("RetrieveFineTuningJob: " + err.Error())
+		}
+
+		mirrorFineTuningJob(ctx, fs, job)
+
+		printJSON(job)
+	case "cancel":
+		if len(args) < 2 {
+			panic("finetune cancel requires a job ID")
+		}
+
+		job, err := client.CancelFineTuningJob(ctx, args[1])
+		if err != nil {
+			panic("CancelFineTuningJob: " + err.Error())
+		}
+
+		mirrorFineTuningJob(ctx, fs, job)
+
+		printJSON(job)
+	case "events":
+		if len(args) < 2 {
+			panic("finetune events requires a job ID")
+		}
+
+		events, err := client.ListFineTuningJobEvents(ctx, args[1])
+		if err != nil {
+			panic("ListFineTuningJobEvents: " + err.Error())
+		}
+
+		printJSON(events)
+	case "register":
+		if len(args) < 3 {
+			panic("finetune register requires a job ID and an assistant ID")
+		}
+
+		asst, err := assistant.RegisterFineTunedModel(ctx, client, args[1], args[2])
+		if err != nil {
+			panic("RegisterFineTunedModel: " + err.Error())
+		}
+
+		printJSON(asst)
+	default:
+		panic(fmt.Sprintf("unknown finetune subcommand %q", args[0]))
+	}
+}
+
+// mirrorFineTuningJob refreshes `job`'s tracked status in `fs`, so
+// `finetune list` reflects what `get`/`cancel` just observed instead of the
+// status last seen at `create` time. Errors are swallowed: the mirror is a
+// convenience for `list`, not the source of truth for `get`/`cancel`.
+func mirrorFineTuningJob(ctx context.Context, fs store.FineTuningStore, job openai.FineTuningJob) {
+	_ = fs.SaveFineTuningJob(ctx, store.FineTuningJob{
+		ID:        job.ID,
+		Model:     job.Model,
+		Status:    job.Status,
+		CreatedAt: job.CreatedAt,
+	})
+}
+
+// printJSON prints `v` as indented JSON.
+func printJSON(v any) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		panic("json.MarshalIndent: " + err.Error())
+	}
+
+	fmt.Println(string(out))
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/thalesfsp/openai-assistant/internal/assistant"
+)
+
+////////////////////
+// Data structures.
+////////////////////
+
+// createThreadRequest is the body of `POST /threads`.
+type createThreadRequest struct {
+	Conversation string `json:"conversation,omitempty"`
+}
+
+// createThreadResponse is the response of `POST /threads`.
+type createThreadResponse struct {
+	ThreadID string `json:"threadID"`
+}
+
+// createMessageRequest is the body of `POST /threads/{id}/messages`.
+type createMessageRequest struct {
+	Content string `json:"content"`
+	Agent   string `json:"agent,omitempty"`
+}
+
+////////////////////
+// Handlers.
+////////////////////
+
+// handleCreateThread implements `POST /threads`.
+func (s *server) handleCreateThread(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+
+		return
+	}
+
+	var req createThreadRequest
+
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err.Error() != "EOF" {
+			writeError(w, http.StatusBadRequest, "decoding request: "+err.Error())
+
+			return
+		}
+	}
+
+	thread, err := assistant.RetrieveOrCreateThreadForConversation(
+		r.Context(),
+		s.client,
+		s.store,
+		req.Conversation,
+		"",
+	)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "creating thread: "+err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, createThreadResponse{ThreadID: thread.ID})
+}
+
+// handleThread routes `/threads/{id}` and `/threads/{id}/messages`.
+func (s *server) handleThread(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/threads/")
+
+	threadID, sub, hasSub := strings.Cut(rest, "/")
+	if threadID == "" {
+		writeError(w, http.StatusNotFound, "not found")
+
+		return
+	}
+
+	switch {
+	case !hasSub && r.Method == http.MethodDelete:
+		s.handleDeleteThread(w, r, threadID)
+	case hasSub && sub == "messages" && r.Method == http.MethodGet:
+		s.handleListMessages(w, r, threadID)
+	case hasSub && sub == "messages" && r.Method == http.MethodPost:
+		s.handleCreateMessage(w, r, threadID)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// handleDeleteThread implements `DELETE /threads/{id}`.
+func (s *server) handleDeleteThread(w http.ResponseWriter, r *http.Request, threadID string) {
+	if _, err := s.client.DeleteThread(r.Context(), threadID); err != nil {
+		writeError(w, http.StatusBadGateway, "deleting thread: "+err.Error())
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListMessages implements `GET /threads/{id}/messages`.
+func (s *server) handleListMessages(w http.ResponseWriter, r *http.Request, threadID string) {
+	msgs, err := assistant.ListMessages(r.Context(), s.client, threadID, nil, nil, nil, nil)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "listing messages: "+err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, assistant.ProcessMessage(msgs))
+}
+
+// handleCreateMessage implements `POST /threads/{id}/messages`, optionally
+// streaming the run's events back as SSE when `?stream=true` is set.
+func (s *server) handleCreateMessage(w http.ResponseWriter, r *http.Request, threadID string) {
+	var req createMessageRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "decoding request: "+err.Error())
+
+		return
+	}
+
+	agent, err := assistant.ResolveAgent(s.agents, req.Agent)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	// Serialize concurrent runs against the same thread. This only holds for
+	// as long as the run itself does: if `?stream=true` and the client
+	// disconnects mid-stream, `r.Context()` cancels, which `streamMessage`
+	// propagates into `SubmitMessageStream` so the run is cancelled on
+	// OpenAI's side before this handler returns and the lock releases —
+	// otherwise a lingering run would reject the next request as
+	// overlapping regardless of this mutex.
+	lock := s.threads.Get(threadID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if r.URL.Query().Get("stream") == "true" {
+		s.streamMessage(w, r, threadID, agent, req.Content)
+
+		return
+	}
+
+	resp, err := assistant.SubmitMessage(
+		r.Context(),
+		s.client,
+		agent.AssistantID,
+		threadID,
+		assistant.RoleUser,
+		req.Content,
+		agent.Instructions,
+		agent.Tools,
+		s.store,
+		"",
+		nil, nil, nil, nil,
+	)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "submitting message: "+err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// streamMessage implements the `?stream=true` branch of
+// `POST /threads/{id}/messages`, writing `RunEvent`s as SSE `data:` frames.
+func (s *server) streamMessage(
+	w http.ResponseWriter,
+	r *http.Request,
+	threadID string,
+	agent assistant.Agent,
+	content string,
+) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported")
+
+		return
+	}
+
+	events, err := assistant.SubmitMessageStream(
+		r.Context(),
+		s.client,
+		agent.AssistantID,
+		threadID,
+		assistant.RoleUser,
+		content,
+		agent.Instructions,
+		agent.Tools,
+	)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "starting stream: "+err.Error())
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	for event := range events {
+		eventJSON, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+
+		fprintfSSE(w, eventJSON)
+		flusher.Flush()
+	}
+}
+
+////////////////////
+// Utilities.
+////////////////////
+
+// writeJSON writes `v` as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a structured JSON error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, apiError{Error: message})
+}
+
+// fprintfSSE writes `data` as a single SSE `data:` frame.
+func fprintfSSE(w http.ResponseWriter, data []byte) {
+	w.Write([]byte("data: "))
+	w.Write(data)
+	w.Write([]byte("\n\n"))
+}
@@ -0,0 +1,67 @@
+// Command server exposes the Assistant as an HTTP/JSON chat API, so it can
+// back a chat UI instead of being driven one-shot from the CLI.
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/thalesfsp/openai-assistant/internal/assistant"
+	"github.com/thalesfsp/openai-assistant/store"
+)
+
+////////////////////
+// Configuration.
+////////////////////
+
+//nolint:go-revive
+const (
+	BotServerAddrEnvVar  = "BOT_SERVER_ADDR"
+	BotServerTokenEnvVar = "BOT_SERVER_TOKEN"
+)
+
+// defaultServerAddr is where the server listens when `BOT_SERVER_ADDR`
+// isn't set.
+const defaultServerAddr = ":8080"
+
+///////////////////
+// Application starts here.
+///////////////////
+
+func main() {
+	cfg := assistant.LoadConfigFromEnv()
+
+	token := assistant.LoadFromEnvVar(true, BotServerTokenEnvVar)
+
+	addr := assistant.LoadFromEnvVar(false, BotServerAddrEnvVar)
+	if addr == "" {
+		addr = defaultServerAddr
+	}
+
+	ts, err := store.NewSQLiteStore(cfg.StorePath)
+	if err != nil {
+		log.Fatal("store.NewSQLiteStore: " + err.Error())
+	}
+	defer ts.Close()
+
+	srv := &server{
+		client:  openai.NewClient(cfg.OpenAIKey),
+		agents:  assistant.DefaultAgents(cfg.AssistantID),
+		store:   ts,
+		token:   token,
+		threads: newThreadLocks(),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/threads", srv.withAuth(srv.handleCreateThread))
+	mux.HandleFunc("/threads/", srv.withAuth(srv.handleThread))
+
+	fmt.Printf("listening on %s\n", addr)
+
+	if err := http.ListenAndServe(addr, mux); err != nil && err != http.ErrServerClosed {
+		log.Fatal("http.ListenAndServe: " + err.Error())
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/thalesfsp/openai-assistant/internal/assistant"
+	"github.com/thalesfsp/openai-assistant/store"
+)
+
+////////////////////
+// Data structures.
+////////////////////
+
+// server bundles everything the HTTP handlers need.
+type server struct {
+	client  *openai.Client
+	agents  map[string]assistant.Agent
+	store   store.ThreadStore
+	token   string
+	threads *threadLocks
+}
+
+// apiError is the structured error response every handler returns on
+// failure.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+////////////////////
+// Per-thread locking.
+////////////////////
+
+// threadLocks hands out a mutex per thread ID, so concurrent requests
+// against the same thread are serialized (OpenAI rejects overlapping runs
+// on the same thread).
+type threadLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// newThreadLocks returns an empty threadLocks registry.
+func newThreadLocks() *threadLocks {
+	return &threadLocks{locks: map[string]*sync.Mutex{}}
+}
+
+// Get returns the mutex for `threadID`, creating one if needed.
+func (tl *threadLocks) Get(threadID string) *sync.Mutex {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	lock, ok := tl.locks[threadID]
+	if !ok {
+		lock = &sync.Mutex{}
+		tl.locks[threadID] = lock
+	}
+
+	return lock
+}
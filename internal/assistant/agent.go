@@ -0,0 +1,61 @@
+package assistant
+
+import "fmt"
+
+////////////////////
+// Data structures.
+////////////////////
+
+// Agent bundles an assistant ID, an optional system-prompt override, and the
+// subset of tools it's allowed to call, so a single binary can front
+// multiple, differently-scoped assistants.
+type Agent struct {
+	// AssistantID is the OpenAI Assistant this Agent runs against.
+	AssistantID string
+
+	// Instructions, when set, overrides the Assistant's own instructions
+	// for the duration of the run (`RunRequest.Instructions`).
+	Instructions string
+
+	// Tools is the subset of the Toolbox this Agent may call.
+	Tools Toolbox
+}
+
+////////////////////
+// Application logic.
+////////////////////
+
+// DefaultAgents returns the built-in Agent registry, selectable from the
+// CLI via `-a/--agent` or from the server via the agent field on a request.
+//
+// NOTE: The "default" Agent mirrors the plain, no-tools setup, so omitting
+// an agent selection keeps working as before.
+func DefaultAgents(assistantID string) map[string]Agent {
+	return map[string]Agent{
+		"default": {
+			AssistantID: assistantID,
+		},
+		"coder": {
+			AssistantID: assistantID,
+			Instructions: "You are a coding assistant. Use the read_file, " +
+				"modify_file, and shell_exec tools to inspect and change files " +
+				"on disk before answering.",
+			Tools: BuiltinToolbox,
+		},
+	}
+}
+
+// ResolveAgent looks up an Agent by name in `agents`, falling back to
+// "default" when `name` is empty.
+func ResolveAgent(agents map[string]Agent, name string) (Agent, error) {
+	if name == "" {
+		name = "default"
+	}
+
+	agent, ok := agents[name]
+	if !ok {
+		return Agent{}, fmt.Errorf("unknown agent %q", name)
+	}
+
+	return agent, nil
+}
@@ -0,0 +1,76 @@
+// Package assistant holds the OpenAI Assistants API core — thread/run
+// management, streaming, tool-calling, and Agents — shared by the CLI
+// (package main, at the repository root) and the HTTP server
+// (`cmd/server`).
+package assistant
+
+import (
+	"fmt"
+	"os"
+)
+
+////////////////////
+// Configuration.
+////////////////////
+
+//nolint:go-revive
+const (
+	BotAssistantIDEnvVar = "BOT_ASSISTANT_ID"
+	BotOpenAIKeyEnvVar   = "BOT_OPENAI_API_KEY"
+	BotOpenAIOrgEnvVar   = "BOT_OPENAI_ORG_ID"
+	BotStorePathEnvVar   = "BOT_STORE_PATH"
+)
+
+// DefaultStorePath is where the local thread/message store lives when
+// `BOT_STORE_PATH` isn't set.
+const DefaultStorePath = "openai-assistant.db"
+
+////////////////////
+// Data structures.
+////////////////////
+
+// Config is the set of environment-driven values every entrypoint
+// (CLI, server) needs to talk to OpenAI and the local store.
+type Config struct {
+	AssistantID string
+	OpenAIKey   string
+	OpenAIOrg   string
+	StorePath   string
+}
+
+////////////////////
+// Utilities.
+////////////////////
+
+// LoadConfigFromEnv reads `Config` from the environment, panicking if a
+// required variable is unset.
+func LoadConfigFromEnv() Config {
+	storePath := LoadFromEnvVar(false, BotStorePathEnvVar)
+	if storePath == "" {
+		storePath = DefaultStorePath
+	}
+
+	return Config{
+		AssistantID: LoadFromEnvVar(true, BotAssistantIDEnvVar),
+		OpenAIKey:   LoadFromEnvVar(true, BotOpenAIKeyEnvVar, "OPENAI_API_KEY"),
+		OpenAIOrg:   LoadFromEnvVar(false, BotOpenAIOrgEnvVar, "OPENAI_ORG_ID"),
+		StorePath:   storePath,
+	}
+}
+
+// LoadFromEnvVar loads the value from the given environment variables.
+func LoadFromEnvVar(required bool, keys ...string) string {
+	for _, key := range keys {
+		value := os.Getenv(key)
+
+		if value != "" {
+			return value
+		}
+	}
+
+	if required {
+		panic(fmt.Sprintf("One of %v needs to be set", keys))
+	}
+
+	return ""
+}
@@ -0,0 +1,126 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/thalesfsp/openai-assistant/store"
+)
+
+////////////////////
+// Errors.
+////////////////////
+
+var (
+	// ErrNotAddressed is returned by `SubmitMessageIfEligible` when the
+	// thread has more than one participant and the message doesn't
+	// mention the assistant via the policy's `MentionToken`.
+	ErrNotAddressed = errors.New("assistant: message does not address the assistant")
+
+	// ErrTooManyParticipants is returned by `SubmitMessageIfEligible` when
+	// the thread has more distinct participants than `MaxParticipants`
+	// allows.
+	ErrTooManyParticipants = errors.New("assistant: too many participants")
+)
+
+////////////////////
+// Data structures.
+////////////////////
+
+// Participant is one of the humans sharing a thread, e.g. a Slack/Matrix/
+// Telegram user bridged into the conversation.
+type Participant struct {
+	ID   string
+	Name string
+}
+
+// ConversationPolicy decides whether an inbound message on a shared thread
+// should trigger a run, modeled on a group-chat matcher: a thread is
+// eligible when it has at most `MaxParticipants` distinct participants, and
+// — once more than one participant is present — the message explicitly
+// addresses the assistant via `MentionToken`.
+//
+// There's no separate "text-only content" check: `Eligible` takes `content`
+// as a plain string, so a non-text payload (an image, a bridged
+// attachment, ...) can't reach it in the first place — that distinction
+// belongs to whatever integration converts the inbound payload to a string
+// before calling `SubmitMessageIfEligible`, not to the policy itself.
+type ConversationPolicy struct {
+	// MaxParticipants is the maximum number of distinct participants a
+	// thread may have for messages to still trigger a run.
+	MaxParticipants int
+
+	// MentionToken is the token (e.g. "@bot") a message must contain to
+	// address the assistant once a thread has more than one participant.
+	// An empty MentionToken means group messages are never eligible.
+	MentionToken string
+}
+
+////////////////////
+// Application logic.
+////////////////////
+
+// Eligible reports whether a message from `participants` with the given
+// `content` should trigger a run under this policy.
+func (p ConversationPolicy) Eligible(participants []Participant, content string) error {
+	distinct := map[string]bool{}
+
+	for _, participant := range participants {
+		distinct[participant.ID] = true
+	}
+
+	if len(distinct) > p.MaxParticipants {
+		return ErrTooManyParticipants
+	}
+
+	// A 1:1 thread doesn't need an explicit mention.
+	if len(distinct) <= 1 {
+		return nil
+	}
+
+	if p.MentionToken == "" || !strings.Contains(content, p.MentionToken) {
+		return ErrNotAddressed
+	}
+
+	return nil
+}
+
+// SubmitMessageIfEligible submits `content` to the thread, exactly like
+// `SubmitMessage`, but first checks it against `policy`. It returns
+// `(nil, ErrNotAddressed)` or `(nil, ErrTooManyParticipants)` when the
+// policy rejects the message, so integrators bridging a shared thread
+// across multiple users don't trigger a run on every message.
+func SubmitMessageIfEligible(
+	ctx context.Context,
+	client *openai.Client,
+	assistantID string,
+	threadID string,
+	policy ConversationPolicy,
+	participants []Participant,
+	content string,
+	instructions string,
+	toolbox Toolbox,
+	ts store.ThreadStore,
+	conversation string,
+) (*SubmitMessageResponse, error) {
+	if err := policy.Eligible(participants, content); err != nil {
+		return nil, err
+	}
+
+	return SubmitMessage(
+		ctx,
+		client,
+		assistantID,
+		threadID,
+		RoleUser,
+		content,
+		instructions,
+		toolbox,
+		ts,
+		conversation,
+		nil, nil, nil, nil,
+	)
+}
@@ -0,0 +1,25 @@
+package assistant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConversationPolicy_Eligible(t *testing.T) {
+	policy := ConversationPolicy{MaxParticipants: 2, MentionToken: "@bot"}
+
+	alice := Participant{ID: "alice"}
+	bob := Participant{ID: "bob"}
+	carol := Participant{ID: "carol"}
+
+	// A 1:1 thread doesn't need a mention.
+	assert.NoError(t, policy.Eligible([]Participant{alice}, "hello"))
+
+	// A group thread needs the mention token.
+	assert.ErrorIs(t, policy.Eligible([]Participant{alice, bob}, "hello"), ErrNotAddressed)
+	assert.NoError(t, policy.Eligible([]Participant{alice, bob}, "hello @bot"))
+
+	// Too many distinct participants is rejected outright.
+	assert.ErrorIs(t, policy.Eligible([]Participant{alice, bob, carol}, "hello @bot"), ErrTooManyParticipants)
+}
@@ -0,0 +1,100 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/thalesfsp/openai-assistant/store"
+)
+
+////////////////////
+// Application logic.
+////////////////////
+
+// UploadTrainingFile uploads the JSONL training file at `path` to the Files
+// API under the `fine-tune` purpose, returning the `openai.File` whose ID
+// is what a `FineTuningJobRequest.TrainingFile` expects.
+func UploadTrainingFile(
+	ctx context.Context,
+	client *openai.Client,
+	path string,
+) (openai.File, error) {
+	return client.CreateFile(ctx, openai.FileRequest{
+		FileName: filepath.Base(path),
+		FilePath: path,
+		Purpose:  string(openai.PurposeFineTune),
+	})
+}
+
+// CreateFineTuningJob uploads `trainingFilePath` and starts a fine-tuning
+// job on top of `model`, mirroring the created job into `fs` — the
+// Fine-tuning API doesn't expose a "list all jobs" endpoint, so this is
+// what backs `finetune list`. `fs` may be nil to skip mirroring.
+func CreateFineTuningJob(
+	ctx context.Context,
+	client *openai.Client,
+	fs store.FineTuningStore,
+	trainingFilePath string,
+	model string,
+	suffix string,
+) (openai.FineTuningJob, error) {
+	file, err := UploadTrainingFile(ctx, client, trainingFilePath)
+	if err != nil {
+		return openai.FineTuningJob{}, fmt.Errorf("uploading training file: %w", err)
+	}
+
+	job, err := client.CreateFineTuningJob(ctx, openai.FineTuningJobRequest{
+		TrainingFile: file.ID,
+		Model:        model,
+		Suffix:       suffix,
+	})
+	if err != nil {
+		// The job never started, so don't leave the upload behind against
+		// the caller's Files quota.
+		_ = client.DeleteFile(ctx, file.ID)
+
+		return job, fmt.Errorf("creating fine-tuning job: %w", err)
+	}
+
+	if fs != nil {
+		if err := fs.SaveFineTuningJob(ctx, store.FineTuningJob{
+			ID:        job.ID,
+			Model:     job.Model,
+			Status:    job.Status,
+			CreatedAt: job.CreatedAt,
+		}); err != nil {
+			return job, fmt.Errorf("saving fine-tuning job %q: %w", job.ID, err)
+		}
+	}
+
+	return job, nil
+}
+
+// RegisterFineTunedModel retrieves `jobID` and, once it has produced a
+// fine-tuned model, points `assistantID` at it via `ModifyAssistant`. It
+// errors if the job hasn't finished (or failed to produce a model) yet.
+func RegisterFineTunedModel(
+	ctx context.Context,
+	client *openai.Client,
+	jobID string,
+	assistantID string,
+) (openai.Assistant, error) {
+	job, err := client.RetrieveFineTuningJob(ctx, jobID)
+	if err != nil {
+		return openai.Assistant{}, fmt.Errorf("retrieving fine-tuning job %q: %w", jobID, err)
+	}
+
+	if job.FineTunedModel == "" {
+		return openai.Assistant{}, fmt.Errorf(
+			"fine-tuning job %q has no fine-tuned model yet (status %q)",
+			jobID, job.Status,
+		)
+	}
+
+	return client.ModifyAssistant(ctx, assistantID, openai.AssistantRequest{
+		Model: job.FineTunedModel,
+	})
+}
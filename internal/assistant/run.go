@@ -0,0 +1,349 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+////////////////////
+// Errors.
+////////////////////
+
+// ErrRunFailed is returned by WaitForRunCompletion when the run reaches a
+// terminal status other than `completed` (or one of RunConfig's
+// AcceptStatuses). Use errors.Is against this, and inspect the wrapped
+// `openai.Run` for the actual status, via errors.As on *RunFailedError.
+var ErrRunFailed = errors.New("assistant: run did not complete successfully")
+
+// RunFailedError wraps ErrRunFailed with the run that reached the
+// unsuccessful terminal status.
+type RunFailedError struct {
+	Run openai.Run
+}
+
+// Error implements the error interface.
+func (e *RunFailedError) Error() string {
+	return fmt.Sprintf("%s: status %q", ErrRunFailed, e.Run.Status)
+}
+
+// Unwrap allows `errors.Is(err, ErrRunFailed)` to succeed.
+func (e *RunFailedError) Unwrap() error {
+	return ErrRunFailed
+}
+
+////////////////////
+// Configuration.
+////////////////////
+
+// RunConfig controls how WaitForRunCompletion polls a run: the backoff
+// between retrieval attempts, how long it's willing to wait overall, and
+// which terminal statuses besides `completed` it treats as success.
+type RunConfig struct {
+	// InitialInterval is how long to wait before the first re-check.
+	InitialInterval time.Duration
+
+	// MaxInterval caps how large the backoff is allowed to grow.
+	MaxInterval time.Duration
+
+	// Multiplier is applied to the interval after every retrieval that
+	// doesn't finish the run.
+	Multiplier float64
+
+	// Jitter is the fraction (0-1) of randomness mixed into each interval,
+	// to avoid many concurrent runs polling in lockstep.
+	Jitter float64
+
+	// MaxWait is the total time WaitForRunCompletion is willing to spend
+	// before giving up and cancelling the run.
+	MaxWait time.Duration
+
+	// AcceptStatuses are terminal statuses, besides `completed`, that
+	// WaitForRunCompletion returns successfully instead of failing with
+	// ErrRunFailed, e.g. a caller that wants to inspect a `cancelled` run
+	// itself rather than treat it as an error.
+	AcceptStatuses []openai.RunStatus
+}
+
+// RunOption customizes a RunConfig built by NewRunConfig.
+type RunOption func(*RunConfig)
+
+// NewRunConfig builds the default RunConfig (1s initial interval, doubling
+// up to 15s with 10% jitter, 60s max wait), applying `opts` on top.
+func NewRunConfig(opts ...RunOption) RunConfig {
+	cfg := RunConfig{
+		InitialInterval: 1 * time.Second,
+		MaxInterval:     15 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.1,
+		MaxWait:         60 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// WithInitialInterval overrides the interval waited before the first
+// re-check.
+func WithInitialInterval(d time.Duration) RunOption {
+	return func(c *RunConfig) { c.InitialInterval = d }
+}
+
+// WithMaxInterval overrides the cap the backoff is allowed to grow to.
+func WithMaxInterval(d time.Duration) RunOption {
+	return func(c *RunConfig) { c.MaxInterval = d }
+}
+
+// WithMultiplier overrides the backoff growth factor applied between
+// retrievals.
+func WithMultiplier(m float64) RunOption {
+	return func(c *RunConfig) { c.Multiplier = m }
+}
+
+// WithJitter overrides the fraction (0-1) of randomness mixed into each
+// interval.
+func WithJitter(j float64) RunOption {
+	return func(c *RunConfig) { c.Jitter = j }
+}
+
+// WithMaxWait overrides how long WaitForRunCompletion is willing to wait in
+// total before giving up and cancelling the run.
+func WithMaxWait(d time.Duration) RunOption {
+	return func(c *RunConfig) { c.MaxWait = d }
+}
+
+// WithAcceptStatuses overrides which terminal statuses, besides
+// `completed`, are treated as success rather than ErrRunFailed.
+func WithAcceptStatuses(statuses ...openai.RunStatus) RunOption {
+	return func(c *RunConfig) { c.AcceptStatuses = statuses }
+}
+
+// nextInterval returns the backoff duration to wait after `attempt`
+// (0-indexed) non-terminal retrievals, per `cfg`.
+func (cfg RunConfig) nextInterval(attempt int) time.Duration {
+	interval := float64(cfg.InitialInterval) * math.Pow(cfg.Multiplier, float64(attempt))
+
+	if cfg.MaxInterval > 0 && interval > float64(cfg.MaxInterval) {
+		interval = float64(cfg.MaxInterval)
+	}
+
+	if cfg.Jitter > 0 {
+		interval += interval * cfg.Jitter * (rand.Float64()*2 - 1)
+	}
+
+	if interval < 0 {
+		interval = 0
+	}
+
+	return time.Duration(interval)
+}
+
+// accepts reports whether `status` should end the poll loop successfully.
+func (cfg RunConfig) accepts(status openai.RunStatus) bool {
+	if status == openai.RunStatusCompleted {
+		return true
+	}
+
+	for _, accepted := range cfg.AcceptStatuses {
+		if accepted == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTerminal reports whether `status` is one the Assistants API never
+// transitions out of.
+func isTerminal(status openai.RunStatus) bool {
+	switch status {
+	case openai.RunStatusCompleted,
+		openai.RunStatusFailed,
+		openai.RunStatusCancelled,
+		openai.RunStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+////////////////////
+// Application logic.
+////////////////////
+
+// WaitForRunCompletion waits for the run to complete, or til the context is
+// cancelled or `cfg.MaxWait` elapses, polling with the backoff `cfg`
+// describes.
+//
+// Whenever the run enters `requires_action`, the tool calls it surfaces are
+// dispatched against `toolbox` and their outputs submitted back, so the run
+// can keep progressing. `toolbox` may be nil if the Agent has no tools.
+//
+// A run that reaches a terminal status other than `completed` (or one of
+// `cfg.AcceptStatuses`) is reported as a *RunFailedError. If `ctx` is
+// cancelled or `cfg.MaxWait` is exceeded while the run is still in flight,
+// WaitForRunCompletion calls `client.CancelRun` before returning the
+// context's error.
+func WaitForRunCompletion(
+	ctx context.Context,
+	client *openai.Client,
+	threadID, runID string,
+	toolbox Toolbox,
+	opts ...RunOption,
+) (openai.Run, error) {
+	cfg := NewRunConfig(opts...)
+
+	if cfg.MaxWait > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, cfg.MaxWait)
+		defer cancel()
+	}
+
+	var (
+		run     openai.Run
+		err     error
+		attempt int
+	)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return cancelRun(client, threadID, runID, err)
+		}
+
+		// Retrieve the run.
+		run, err = client.RetrieveRun(ctx, threadID, runID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return cancelRun(client, threadID, runID, ctx.Err())
+			}
+
+			return run, err
+		}
+
+		// Stop the loop if the run reached a status this call accepts.
+		if cfg.accepts(run.Status) {
+			break
+		}
+
+		// Stop the loop, with an error, if the run reached any other
+		// terminal status.
+		if isTerminal(run.Status) {
+			return run, &RunFailedError{Run: run}
+		}
+
+		// Dispatch any pending tool calls, and submit their outputs, so the
+		// run can resume.
+		if run.Status == openai.RunStatusRequiresAction && run.RequiredAction != nil {
+			run, err = submitRequiredToolOutputs(ctx, client, threadID, run, toolbox)
+			if err != nil {
+				if ctx.Err() != nil {
+					return cancelRun(client, threadID, runID, ctx.Err())
+				}
+
+				return run, err
+			}
+
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return cancelRun(client, threadID, runID, ctx.Err())
+		case <-time.After(cfg.nextInterval(attempt)):
+		}
+
+		attempt++
+	}
+
+	return run, nil
+}
+
+// cancelRun best-effort cancels the run on OpenAI's side before returning
+// `cause` to the caller, so a caller-cancelled context (or an exceeded
+// `RunConfig.MaxWait`) doesn't leave the run going in the background.
+func cancelRun(client *openai.Client, threadID, runID string, cause error) (openai.Run, error) {
+	// NOTE: `ctx` is already done, so a fresh one is needed for the cancel
+	// call itself.
+	cancelCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	run, _ := client.CancelRun(cancelCtx, threadID, runID)
+
+	return run, cause
+}
+
+// submitRequiredToolOutputs dispatches every tool call a `requires_action`
+// run is waiting on against `toolbox`, and submits their outputs.
+func submitRequiredToolOutputs(
+	ctx context.Context,
+	client *openai.Client,
+	threadID string,
+	run openai.Run,
+	toolbox Toolbox,
+) (openai.Run, error) {
+	calls := run.RequiredAction.SubmitToolOutputs.ToolCalls
+
+	outputs := make([]openai.ToolOutput, 0, len(calls))
+
+	for _, call := range calls {
+		outputs = append(outputs, toolbox.Dispatch(ctx, call))
+	}
+
+	return client.SubmitToolOutputs(
+		ctx,
+		threadID,
+		run.ID,
+		openai.SubmitToolOutputsRequest{
+			ToolOutputs: outputs,
+		},
+	)
+}
+
+// CreateRunAndRun creates a run and waits for it to complete, dispatching
+// tool calls against `toolbox` as they come up. `instructions`, when
+// non-empty, overrides the Assistant's own instructions for this run.
+//
+// Unlike earlier versions of this function, `ctx` is used for the whole
+// operation — including the wait — so cancelling it (or letting it expire)
+// now cancels the run itself. Use `opts` to tune the polling backoff and
+// overall wait budget; see NewRunConfig for the defaults.
+func CreateRunAndRun(
+	ctx context.Context,
+	client *openai.Client,
+	threadID string,
+	assistantID string,
+	instructions string,
+	toolbox Toolbox,
+	opts ...RunOption,
+) (openai.Run, error) {
+	run, err := client.CreateRun(
+		ctx,
+		threadID,
+		openai.RunRequest{
+			AssistantID:  assistantID,
+			Instructions: instructions,
+			Tools:        toolbox.Tools(),
+		},
+	)
+	if err != nil {
+		return run, err
+	}
+
+	return WaitForRunCompletion(
+		ctx,
+		client,
+		threadID,
+		run.ID,
+		toolbox,
+		opts...,
+	)
+}
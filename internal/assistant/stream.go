@@ -0,0 +1,249 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+////////////////////
+// Configuration.
+////////////////////
+
+// streamPollInterval is how often the stream checks the run status and
+// thread messages for updates.
+//
+// NOTE: go-openai does not yet expose the Assistants SSE streaming
+// endpoints (`CreateThreadAndStream` / `CreateRunStream`), so this
+// approximates streaming by polling the same endpoints `waitForRunCompletion`
+// uses and emitting an event whenever something changes.
+const streamPollInterval = 1 * time.Second
+
+////////////////////
+// Data structures.
+////////////////////
+
+// RunEventType identifies the kind of event emitted while a run streams
+// towards completion.
+type RunEventType string
+
+const (
+	// RunEventCreated is emitted once, right after the run is created.
+	RunEventCreated RunEventType = "run.created"
+
+	// RunEventMessageDelta is emitted whenever a new message shows up in
+	// the thread while the run is in flight.
+	RunEventMessageDelta RunEventType = "message.delta"
+
+	// RunEventToolCallDelta is emitted whenever the run surfaces a
+	// `requires_action` tool call while streaming.
+	RunEventToolCallDelta RunEventType = "tool_call.delta"
+
+	// RunEventCompleted is emitted once the run reaches a successful
+	// terminal state.
+	RunEventCompleted RunEventType = "run.completed"
+
+	// RunEventFailed is emitted once the run reaches a non-successful
+	// terminal state, or the stream itself fails.
+	RunEventFailed RunEventType = "run.failed"
+)
+
+// RunEvent is a single event emitted on the channel returned by
+// `SubmitMessageStream`.
+type RunEvent struct {
+	Type      RunEventType      `json:"type"`
+	Run       *openai.Run       `json:"run,omitempty"`
+	Message   *ProcessedMessage `json:"message,omitempty"`
+	ToolCalls []openai.ToolCall `json:"toolCalls,omitempty"`
+	Err       string            `json:"error,omitempty"`
+}
+
+////////////////////
+// Application logic.
+////////////////////
+
+// SubmitMessageStream mirrors `submitMessage`, but instead of blocking until
+// the run completes, it creates the message/run and returns a channel of
+// typed events so callers can render progress as it happens.
+//
+// `instructions`, when non-empty, overrides the Assistant's own
+// instructions for this run, exactly like `CreateRunAndRun`. `toolbox` is
+// advertised to the run, so the model can actually call it; it may be nil
+// if the Agent has no tools.
+//
+// The returned channel is closed once the run reaches a terminal state
+// (`RunEventCompleted` or `RunEventFailed`). A runner error terminates the
+// stream with a `RunEventFailed` event rather than silently closing the
+// channel.
+func SubmitMessageStream(
+	ctx context.Context,
+	client *openai.Client,
+	assistantID string,
+	threadID string,
+	role openai.ThreadMessageRole,
+	content string,
+	instructions string,
+	toolbox Toolbox,
+) (<-chan RunEvent, error) {
+	if _, err := CreateMessage(ctx, client, threadID, role, content); err != nil {
+		return nil, err
+	}
+
+	// Snapshot the thread's messages before the run starts, so the poll
+	// loop only reports messages that show up afterwards — not the
+	// question just submitted above or, for an existing thread, its whole
+	// prior history.
+	seen, err := seenMessageIDs(ctx, client, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := client.CreateRun(
+		ctx,
+		threadID,
+		openai.RunRequest{
+			AssistantID:  assistantID,
+			Instructions: instructions,
+			Tools:        toolbox.Tools(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan RunEvent)
+
+	go streamRun(ctx, client, threadID, run, toolbox, seen, events)
+
+	return events, nil
+}
+
+// streamRun polls the run and thread messages, emitting an event for every
+// change, until the run reaches a terminal state. Whenever the run enters
+// `requires_action`, its tool calls are dispatched against `toolbox` and
+// their outputs submitted back, same as `WaitForRunCompletion`, so a
+// tool-using Agent can stream too instead of spinning on `tool_call.delta`
+// until the run expires.
+func streamRun(
+	ctx context.Context,
+	client *openai.Client,
+	threadID string,
+	run openai.Run,
+	toolbox Toolbox,
+	seen map[string]bool,
+	events chan<- RunEvent,
+) {
+	defer close(events)
+
+	events <- RunEvent{Type: RunEventCreated, Run: &run}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Best-effort cancel on OpenAI's side, same as
+			// WaitForRunCompletion, so a disconnected caller doesn't leave
+			// the run going in the background.
+			_, _ = cancelRun(client, threadID, run.ID, ctx.Err())
+
+			events <- RunEvent{Type: RunEventFailed, Err: ctx.Err().Error()}
+
+			return
+		default:
+		}
+
+		var err error
+
+		run, err = client.RetrieveRun(ctx, threadID, run.ID)
+		if err != nil {
+			events <- RunEvent{Type: RunEventFailed, Err: err.Error()}
+
+			return
+		}
+
+		if run.Status == openai.RunStatusRequiresAction && run.RequiredAction != nil {
+			events <- RunEvent{
+				Type:      RunEventToolCallDelta,
+				Run:       &run,
+				ToolCalls: run.RequiredAction.SubmitToolOutputs.ToolCalls,
+			}
+
+			run, err = submitRequiredToolOutputs(ctx, client, threadID, run, toolbox)
+			if err != nil {
+				events <- RunEvent{Type: RunEventFailed, Run: &run, Err: err.Error()}
+
+				return
+			}
+
+			continue
+		}
+
+		for _, msg := range newMessagesSince(ctx, client, threadID, seen) {
+			events <- RunEvent{Type: RunEventMessageDelta, Run: &run, Message: &msg}
+		}
+
+		switch run.Status {
+		case openai.RunStatusCompleted:
+			events <- RunEvent{Type: RunEventCompleted, Run: &run}
+
+			return
+		case openai.RunStatusFailed, openai.RunStatusCancelled, openai.RunStatusExpired:
+			events <- RunEvent{Type: RunEventFailed, Run: &run, Err: fmt.Sprintf("run ended with status %q", run.Status)}
+
+			return
+		}
+
+		time.Sleep(streamPollInterval)
+	}
+}
+
+// seenMessageIDs lists `threadID`'s current messages and returns their IDs,
+// ready to seed `newMessagesSince` so it only reports messages that arrive
+// afterwards.
+func seenMessageIDs(
+	ctx context.Context,
+	client *openai.Client,
+	threadID string,
+) (map[string]bool, error) {
+	msgs, err := ListMessages(ctx, client, threadID, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(msgs.Messages))
+
+	for _, processed := range ProcessMessage(msgs) {
+		seen[processed.ID] = true
+	}
+
+	return seen, nil
+}
+
+// newMessagesSince lists the thread's messages and returns the ones not yet
+// present in `seen`, marking them as seen as a side effect.
+func newMessagesSince(
+	ctx context.Context,
+	client *openai.Client,
+	threadID string,
+	seen map[string]bool,
+) []ProcessedMessage {
+	msgs, err := ListMessages(ctx, client, threadID, nil, nil, nil, nil)
+	if err != nil {
+		return nil
+	}
+
+	fresh := []ProcessedMessage{}
+
+	for _, processed := range ProcessMessage(msgs) {
+		if seen[processed.ID] {
+			continue
+		}
+
+		seen[processed.ID] = true
+
+		fresh = append(fresh, processed)
+	}
+
+	return fresh
+}
@@ -0,0 +1,130 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/thalesfsp/openai-assistant/store"
+)
+
+// SubmitMessage submits a message to the given thread. When `ts` is
+// non-nil, every processed message is mirrored into it under `conversation`,
+// so history survives OpenAI's thread retention window.
+func SubmitMessage(
+	ctx context.Context,
+	client *openai.Client,
+	assistantID string,
+	threadID string,
+	role openai.ThreadMessageRole,
+	content string,
+	instructions string,
+	toolbox Toolbox,
+	ts store.ThreadStore,
+	conversation string,
+	limit *int,
+	order *string,
+	after *string,
+	before *string,
+) (*SubmitMessageResponse, error) {
+	start := time.Now()
+
+	msg, err := CreateMessage(ctx, client, threadID, role, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: `ctx` now drives the run itself, so a caller-cancelled context
+	// (or one with a deadline) cancels the run on OpenAI's side too — see
+	// CreateRunAndRun.
+	run, err := CreateRunAndRun(
+		ctx,
+		client,
+		threadID,
+		assistantID,
+		instructions,
+		toolbox,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := ListMessages(
+		ctx,
+		client,
+		threadID,
+		limit,
+		order,
+		after,
+		before,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	processedMessages := ProcessMessage(msgs)
+
+	if ts != nil && conversation != "" {
+		if err := mirrorMessages(ctx, ts, conversation, processedMessages); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SubmitMessageResponse{
+		CompletedRun:      run,
+		CreatedMessage:    msg,
+		ExecutionTime:     time.Since(start),
+		ProcessedMessages: processedMessages,
+		RawMessages:       msgs,
+	}, nil
+}
+
+// mirrorMessages appends every processed message to `ts` under
+// `conversation`.
+func mirrorMessages(
+	ctx context.Context,
+	ts store.ThreadStore,
+	conversation string,
+	messages []ProcessedMessage,
+) error {
+	for _, message := range messages {
+		err := ts.AppendMessage(ctx, conversation, store.Message{
+			ID:        message.ID,
+			ThreadID:  message.ThreadID,
+			Role:      message.Role,
+			Content:   message.Value,
+			CreatedAt: int64(message.CreatedAt),
+		})
+		if err != nil {
+			return fmt.Errorf("mirroring message %q: %w", message.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// ProcessMessage processes the messages.
+func ProcessMessage(msgs openai.MessagesList) []ProcessedMessage {
+	processedMessages := []ProcessedMessage{}
+
+	for _, message := range msgs.Messages {
+		for _, content := range message.Content {
+			// Ensure to add only messages with content.
+			if content.Text == nil {
+				continue
+			}
+
+			processedMessages = append(processedMessages, ProcessedMessage{
+				CreatedAt: message.CreatedAt,
+				ID:        message.ID,
+				Role:      message.Role,
+				ThreadID:  message.ThreadID,
+				Value:     content.Text.Value,
+			})
+		}
+	}
+
+	return processedMessages
+}
@@ -0,0 +1,121 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/thalesfsp/openai-assistant/store"
+)
+
+////////////////////
+// Application logic.
+////////////////////
+
+// RetrieveOrCreateThread retrieves the thread if the thread ID is not
+// empty, otherwise it creates a new thread.
+func RetrieveOrCreateThread(
+	ctx context.Context,
+	client *openai.Client,
+	threadID string,
+) (openai.Thread, error) {
+	// Try to retrieve the thread, ONLY if the thread ID is not empty.
+	if threadID != "" {
+		thread, err := client.RetrieveThread(ctx, threadID)
+		if err != nil {
+			fmt.Println("Error retrieving thread:", err.Error())
+
+			// Return a new thread if the thread does not exist.
+			return client.CreateThread(ctx, openai.ThreadRequest{})
+		}
+
+		// Return the thread if it exists.
+		return thread, nil
+	}
+
+	// Create a new thread.
+	return client.CreateThread(ctx, openai.ThreadRequest{})
+}
+
+// RetrieveOrCreateThreadForConversation resolves `threadID` the same way
+// `RetrieveOrCreateThread` does, but first consults `ts` for a thread
+// already mapped to `conversation`, and persists the mapping for new
+// conversations. `ts` may be nil, in which case it behaves exactly like
+// `RetrieveOrCreateThread`.
+func RetrieveOrCreateThreadForConversation(
+	ctx context.Context,
+	client *openai.Client,
+	ts store.ThreadStore,
+	conversation string,
+	threadID string,
+) (openai.Thread, error) {
+	if ts == nil || conversation == "" {
+		return RetrieveOrCreateThread(ctx, client, threadID)
+	}
+
+	if stored, err := ts.LoadThread(ctx, conversation); err == nil {
+		threadID = stored.ThreadID
+	} else if err != store.ErrNotFound {
+		return openai.Thread{}, fmt.Errorf("loading conversation %q: %w", conversation, err)
+	}
+
+	thread, err := RetrieveOrCreateThread(ctx, client, threadID)
+	if err != nil {
+		return thread, err
+	}
+
+	if err := ts.SaveThread(ctx, store.Thread{
+		Name:      conversation,
+		ThreadID:  thread.ID,
+		CreatedAt: time.Now().Unix(),
+	}); err != nil {
+		return thread, fmt.Errorf("saving conversation %q: %w", conversation, err)
+	}
+
+	return thread, nil
+}
+
+// CreateMessage creates a message in the given thread.
+func CreateMessage(
+	ctx context.Context,
+	client *openai.Client,
+	threadID string,
+	role openai.ThreadMessageRole,
+	content string,
+) (openai.Message, error) {
+	return client.CreateMessage(
+		ctx,
+		threadID,
+		openai.MessageRequest{
+			Role:    string(role),
+			Content: content,
+		},
+	)
+}
+
+// ListMessages lists the messages in the given thread.
+func ListMessages(
+	ctx context.Context,
+	client *openai.Client,
+	threadID string,
+	limit *int,
+	order *string,
+	after *string,
+	before *string,
+) (openai.MessagesList, error) {
+	msgs, err := client.ListMessage(
+		ctx,
+		threadID,
+		limit,
+		order,
+		after,
+		before,
+	)
+	if err != nil {
+		return msgs, err
+	}
+
+	return msgs, nil
+}
@@ -1,4 +1,4 @@
-package main
+package assistant
 
 import (
 	"context"
@@ -9,7 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_retrieveOrCreateThread(t *testing.T) {
+func Test_RetrieveOrCreateThread(t *testing.T) {
 	if os.Getenv("ENVIRONMENT") != "integration" {
 		t.Skip("Skipping integration test")
 	}
@@ -17,13 +17,13 @@ func Test_retrieveOrCreateThread(t *testing.T) {
 	// NOTE: If OrgID needs to be specified, replace `openai.NewClient` with
 	// `openai.NewClientWithConfig`.
 	// WARN: This will create a new thread every time the test is run!
-	var openAIKey = loadFromEnvVar(true, "BOT_OPENAI_API_KEY", "OPENAI_API_KEY")
+	var openAIKey = LoadFromEnvVar(true, "BOT_OPENAI_API_KEY", "OPENAI_API_KEY")
 
 	ctx := context.Background()
 	client := openai.NewClient(openAIKey)
 
 	// Creates a brand new Thread.
-	thread, err := retrieveOrCreateThread(ctx, client, "")
+	thread, err := RetrieveOrCreateThread(ctx, client, "")
 	assert.NoError(t, err)
 
 	// NOTE: Despite being possible to delete A know thread by its ID,
@@ -35,14 +35,14 @@ func Test_retrieveOrCreateThread(t *testing.T) {
 	defer client.DeleteThread(ctx, thread.ID)
 
 	// Retrieves the brand new Thread.
-	retrievedThread, err := retrieveOrCreateThread(ctx, client, thread.ID)
+	retrievedThread, err := RetrieveOrCreateThread(ctx, client, thread.ID)
 	assert.NoError(t, err)
 
 	// Ensure it's the same Thread.
 	assert.Equal(t, thread.ID, retrievedThread.ID)
 
 	// Creates another brand new Thread because the retrieve will fail.
-	newThread, err := retrieveOrCreateThread(ctx, client, "123")
+	newThread, err := RetrieveOrCreateThread(ctx, client, "123")
 	assert.NoError(t, err)
 
 	// NOTE: Despite being possible to delete A know thread by its ID,
@@ -0,0 +1,96 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+////////////////////
+// Data structures.
+////////////////////
+
+// ToolHandler is a Go function backing a tool the Assistant can call.
+//
+// It receives the tool call's raw arguments (as sent by OpenAI) and returns
+// a value to be JSON-encoded as the tool output, or an error.
+type ToolHandler func(ctx context.Context, args json.RawMessage) (any, error)
+
+// Tool bundles a handler with the `FunctionDefinition` advertised to the
+// Assistants API, so a `Toolbox` entry is self-describing.
+type Tool struct {
+	Definition openai.FunctionDefinition
+	Handler    ToolHandler
+}
+
+// Toolbox is a registry of tools, keyed by name, available to be dispatched
+// during a `requires_action` run.
+type Toolbox map[string]Tool
+
+////////////////////
+// Application logic.
+////////////////////
+
+// AssistantTools returns the `openai.AssistantTool` list describing every
+// tool in the Toolbox, suitable for `AssistantRequest.Tools` when
+// registering tools directly on the Assistant (e.g. via `ModifyAssistant`).
+func (tb Toolbox) AssistantTools() []openai.AssistantTool {
+	tools := make([]openai.AssistantTool, 0, len(tb))
+
+	for _, tool := range tb {
+		definition := tool.Definition
+
+		tools = append(tools, openai.AssistantTool{
+			Type:     openai.AssistantToolTypeFunction,
+			Function: &definition,
+		})
+	}
+
+	return tools
+}
+
+// Tools returns the `openai.Tool` list describing every tool in the
+// Toolbox, suitable for `RunRequest.Tools` — this is what actually advertises
+// an Agent's tools to the model for a given run, as opposed to
+// `AssistantTools`, which registers them on the Assistant itself.
+func (tb Toolbox) Tools() []openai.Tool {
+	tools := make([]openai.Tool, 0, len(tb))
+
+	for _, tool := range tb {
+		definition := tool.Definition
+
+		tools = append(tools, openai.Tool{
+			Type:     openai.ToolTypeFunction,
+			Function: &definition,
+		})
+	}
+
+	return tools
+}
+
+// Dispatch runs the handler registered for `call.Function.Name`, decoding
+// its result into a `ToolOutput` ready to be submitted back to the run.
+func (tb Toolbox) Dispatch(ctx context.Context, call openai.ToolCall) openai.ToolOutput {
+	tool, ok := tb[call.Function.Name]
+	if !ok {
+		return openai.ToolOutput{
+			ToolCallID: call.ID,
+			Output:     fmt.Sprintf("error: unknown tool %q", call.Function.Name),
+		}
+	}
+
+	result, err := tool.Handler(ctx, json.RawMessage(call.Function.Arguments))
+	if err != nil {
+		return openai.ToolOutput{
+			ToolCallID: call.ID,
+			Output:     fmt.Sprintf("error: %s", err.Error()),
+		}
+	}
+
+	return openai.ToolOutput{
+		ToolCallID: call.ID,
+		Output:     result,
+	}
+}
@@ -0,0 +1,177 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+////////////////////
+// Configuration.
+////////////////////
+
+//nolint:go-revive
+const (
+	// BotShellAllowlistEnvVar is a comma-separated list of shell commands
+	// (the first whitespace-separated token of the command line) the
+	// `shell_exec` tool is allowed to run. Empty/unset means no command is
+	// allowed.
+	BotShellAllowlistEnvVar = "BOT_SHELL_ALLOWLIST"
+)
+
+// BuiltinToolbox ships a small set of example tools (`read_file`,
+// `modify_file`, `shell_exec`) an Agent can be granted. `shell_exec` is
+// additionally gated behind `BOT_SHELL_ALLOWLIST`.
+var BuiltinToolbox = Toolbox{
+	"read_file": {
+		Definition: openai.FunctionDefinition{
+			Name:        "read_file",
+			Description: "Reads and returns the contents of a file on disk.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"path": map[string]any{
+						"type":        "string",
+						"description": "Path of the file to read.",
+					},
+				},
+				"required": []string{"path"},
+			},
+		},
+		Handler: readFileTool,
+	},
+	"modify_file": {
+		Definition: openai.FunctionDefinition{
+			Name:        "modify_file",
+			Description: "Overwrites a file on disk with the given contents.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"path": map[string]any{
+						"type":        "string",
+						"description": "Path of the file to write.",
+					},
+					"contents": map[string]any{
+						"type":        "string",
+						"description": "New contents of the file.",
+					},
+				},
+				"required": []string{"path", "contents"},
+			},
+		},
+		Handler: modifyFileTool,
+	},
+	"shell_exec": {
+		Definition: openai.FunctionDefinition{
+			Name:        "shell_exec",
+			Description: "Runs a shell command, if its name is allow-listed, and returns its combined output.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"command": map[string]any{
+						"type":        "string",
+						"description": "Command line to run, e.g. \"ls -la\".",
+					},
+				},
+				"required": []string{"command"},
+			},
+		},
+		Handler: shellExecTool,
+	},
+}
+
+////////////////////
+// Application logic.
+////////////////////
+
+type readFileArgs struct {
+	Path string `json:"path"`
+}
+
+// readFileTool implements the `read_file` tool.
+func readFileTool(_ context.Context, rawArgs json.RawMessage) (any, error) {
+	var args readFileArgs
+
+	if err := json.Unmarshal(rawArgs, &args); err != nil {
+		return nil, err
+	}
+
+	contents, err := os.ReadFile(args.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(contents), nil
+}
+
+type modifyFileArgs struct {
+	Path     string `json:"path"`
+	Contents string `json:"contents"`
+}
+
+// modifyFileTool implements the `modify_file` tool.
+func modifyFileTool(_ context.Context, rawArgs json.RawMessage) (any, error) {
+	var args modifyFileArgs
+
+	if err := json.Unmarshal(rawArgs, &args); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(args.Path, []byte(args.Contents), 0o644); err != nil {
+		return nil, err
+	}
+
+	return "ok", nil
+}
+
+type shellExecArgs struct {
+	Command string `json:"command"`
+}
+
+// shellExecTool implements the `shell_exec` tool, refusing to run anything
+// whose first token isn't present in `BOT_SHELL_ALLOWLIST`.
+func shellExecTool(ctx context.Context, rawArgs json.RawMessage) (any, error) {
+	var args shellExecArgs
+
+	if err := json.Unmarshal(rawArgs, &args); err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(args.Command)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+
+	if !isShellCommandAllowed(fields[0]) {
+		return nil, fmt.Errorf("command %q is not allow-listed in %s", fields[0], BotShellAllowlistEnvVar)
+	}
+
+	out, err := exec.CommandContext(ctx, "sh", "-c", args.Command).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(out))
+	}
+
+	return string(out), nil
+}
+
+// isShellCommandAllowed reports whether `name` is present in the
+// comma-separated `BOT_SHELL_ALLOWLIST` environment variable.
+func isShellCommandAllowed(name string) bool {
+	allowlist := os.Getenv(BotShellAllowlistEnvVar)
+	if allowlist == "" {
+		return false
+	}
+
+	for _, allowed := range strings.Split(allowlist, ",") {
+		if strings.TrimSpace(allowed) == name {
+			return true
+		}
+	}
+
+	return false
+}
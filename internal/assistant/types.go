@@ -0,0 +1,54 @@
+package assistant
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+////////////////////
+// Configuration.
+////////////////////
+
+const (
+	// RoleAssistant is the Bot's role in the conversation.
+	RoleAssistant openai.ThreadMessageRole = "assistant"
+
+	// RoleSystem is the Bot's instruction on how to proceed/interpret/behave
+	// regarding the current conversation.
+	RoleSystem openai.ThreadMessageRole = "system"
+
+	// RoleUser is the User's role in the conversation.
+	RoleUser openai.ThreadMessageRole = "user"
+)
+
+var (
+	// MessageIDRegexp matches a valid OpenAI message ID.
+	MessageIDRegexp = regexp.MustCompile(`msg_([a-zA-Z0-9]+)`)
+
+	// ThreadIDRegexp matches a valid OpenAI thread ID.
+	ThreadIDRegexp = regexp.MustCompile(`thread_([a-zA-Z0-9]+)`)
+)
+
+////////////////////
+// Data structures.
+////////////////////
+
+// SubmitMessageResponse is the response from the SubmitMessage function.
+type SubmitMessageResponse struct {
+	CompletedRun      openai.Run          `json:"completedRun"`
+	CreatedMessage    openai.Message      `json:"createdMessage"`
+	ExecutionTime     time.Duration       `json:"executionTime"`
+	ProcessedMessages []ProcessedMessage  `json:"processedMessages"`
+	RawMessages       openai.MessagesList `json:"rawMessages"`
+}
+
+// ProcessedMessage is the processed message.
+type ProcessedMessage struct {
+	CreatedAt int    `json:"createdAt"`
+	ID        string `json:"id"`
+	Role      string `json:"role"`
+	ThreadID  string `json:"threadID"`
+	Value     string `json:"value"`
+}
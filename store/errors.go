@@ -0,0 +1,7 @@
+package store
+
+import "errors"
+
+// ErrNotFound is returned by `ThreadStore.LoadThread` when no thread is
+// stored under the requested conversation name.
+var ErrNotFound = errors.New("store: not found")
@@ -0,0 +1,227 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3" // SQL driver, registered via side effects.
+)
+
+////////////////////
+// Configuration.
+////////////////////
+
+const schema = `
+CREATE TABLE IF NOT EXISTS threads (
+	name       TEXT PRIMARY KEY,
+	thread_id  TEXT NOT NULL,
+	created_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+	id             TEXT NOT NULL,
+	thread_name    TEXT NOT NULL,
+	thread_id      TEXT NOT NULL,
+	role           TEXT NOT NULL,
+	content        TEXT NOT NULL,
+	created_at     INTEGER NOT NULL,
+	PRIMARY KEY (thread_name, id)
+);
+
+CREATE TABLE IF NOT EXISTS fine_tuning_jobs (
+	id         TEXT PRIMARY KEY,
+	model      TEXT NOT NULL,
+	status     TEXT NOT NULL,
+	created_at INTEGER NOT NULL
+);
+`
+
+////////////////////
+// Data structures.
+////////////////////
+
+// SQLiteStore is a `ThreadStore` backed by a local SQLite database.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+var (
+	_ ThreadStore     = (*SQLiteStore)(nil)
+	_ FineTuningStore = (*SQLiteStore)(nil)
+)
+
+////////////////////
+// Factory.
+////////////////////
+
+// NewSQLiteStore opens (creating if needed) the SQLite database at `path`
+// and ensures its schema is in place.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("applying schema: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+////////////////////
+// ThreadStore implementation.
+////////////////////
+
+// SaveThread implements `ThreadStore`.
+func (s *SQLiteStore) SaveThread(ctx context.Context, thread Thread) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO threads (name, thread_id, created_at) VALUES (?, ?, ?)
+		 ON CONFLICT(name) DO UPDATE SET thread_id = excluded.thread_id`,
+		thread.Name, thread.ThreadID, thread.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("saving thread %q: %w", thread.Name, err)
+	}
+
+	return nil
+}
+
+// LoadThread implements `ThreadStore`.
+func (s *SQLiteStore) LoadThread(ctx context.Context, name string) (Thread, error) {
+	var thread Thread
+
+	row := s.db.QueryRowContext(
+		ctx,
+		`SELECT name, thread_id, created_at FROM threads WHERE name = ?`,
+		name,
+	)
+
+	if err := row.Scan(&thread.Name, &thread.ThreadID, &thread.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return Thread{}, ErrNotFound
+		}
+
+		return Thread{}, fmt.Errorf("loading thread %q: %w", name, err)
+	}
+
+	return thread, nil
+}
+
+// ListThreads implements `ThreadStore`.
+func (s *SQLiteStore) ListThreads(ctx context.Context) ([]Thread, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT name, thread_id, created_at FROM threads ORDER BY created_at ASC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing threads: %w", err)
+	}
+	defer rows.Close()
+
+	threads := []Thread{}
+
+	for rows.Next() {
+		var thread Thread
+
+		if err := rows.Scan(&thread.Name, &thread.ThreadID, &thread.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning thread: %w", err)
+		}
+
+		threads = append(threads, thread)
+	}
+
+	return threads, rows.Err()
+}
+
+// DeleteThread implements `ThreadStore`.
+func (s *SQLiteStore) DeleteThread(ctx context.Context, name string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("deleting thread %q: %w", name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM messages WHERE thread_name = ?`, name); err != nil {
+		tx.Rollback()
+
+		return fmt.Errorf("deleting messages for %q: %w", name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM threads WHERE name = ?`, name); err != nil {
+		tx.Rollback()
+
+		return fmt.Errorf("deleting thread %q: %w", name, err)
+	}
+
+	return tx.Commit()
+}
+
+// AppendMessage implements `ThreadStore`.
+func (s *SQLiteStore) AppendMessage(ctx context.Context, name string, message Message) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO messages (id, thread_name, thread_id, role, content, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(thread_name, id) DO NOTHING`,
+		message.ID, name, message.ThreadID, message.Role, message.Content, message.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("appending message to %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// Close implements `ThreadStore`.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+////////////////////
+// FineTuningStore implementation.
+////////////////////
+
+// SaveFineTuningJob implements `FineTuningStore`.
+func (s *SQLiteStore) SaveFineTuningJob(ctx context.Context, job FineTuningJob) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO fine_tuning_jobs (id, model, status, created_at) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET status = excluded.status`,
+		job.ID, job.Model, job.Status, job.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("saving fine-tuning job %q: %w", job.ID, err)
+	}
+
+	return nil
+}
+
+// ListFineTuningJobs implements `FineTuningStore`.
+func (s *SQLiteStore) ListFineTuningJobs(ctx context.Context) ([]FineTuningJob, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT id, model, status, created_at FROM fine_tuning_jobs ORDER BY created_at DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing fine-tuning jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []FineTuningJob{}
+
+	for rows.Next() {
+		var job FineTuningJob
+
+		if err := rows.Scan(&job.ID, &job.Model, &job.Status, &job.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning fine-tuning job: %w", err)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
@@ -0,0 +1,77 @@
+// Package store persists thread/message history locally, so conversations
+// survive OpenAI's 60-day thread retention window.
+package store
+
+import "context"
+
+////////////////////
+// Data structures.
+////////////////////
+
+// Thread is a locally-tracked mirror of an OpenAI thread, keyed by a
+// user-supplied conversation name.
+type Thread struct {
+	Name      string `json:"name"`
+	ThreadID  string `json:"threadID"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// Message is a single message mirrored into the local store.
+type Message struct {
+	ID        string `json:"id"`
+	ThreadID  string `json:"threadID"`
+	Role      string `json:"role"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// FineTuningJob is a locally-tracked record of a fine-tuning job started
+// through this tool. The Fine-tuning API doesn't expose a "list all jobs"
+// endpoint, so `finetune list` relies on this mirror instead.
+type FineTuningJob struct {
+	ID        string `json:"id"`
+	Model     string `json:"model"`
+	Status    string `json:"status"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+////////////////////
+// Interface.
+////////////////////
+
+// ThreadStore persists threads and their messages, keyed by conversation
+// name.
+type ThreadStore interface {
+	// SaveThread upserts a conversation's thread mapping.
+	SaveThread(ctx context.Context, thread Thread) error
+
+	// LoadThread retrieves a conversation's thread mapping. It returns
+	// `ErrNotFound` if no thread is stored under `name`.
+	LoadThread(ctx context.Context, name string) (Thread, error)
+
+	// ListThreads returns every stored conversation, in no particular
+	// order.
+	ListThreads(ctx context.Context) ([]Thread, error)
+
+	// DeleteThread removes a conversation's thread mapping and its
+	// messages.
+	DeleteThread(ctx context.Context, name string) error
+
+	// AppendMessage mirrors a single processed message into the store.
+	AppendMessage(ctx context.Context, name string, message Message) error
+
+	// Close releases the store's underlying resources.
+	Close() error
+}
+
+// FineTuningStore persists locally-created fine-tuning jobs, so they can be
+// listed later despite the Fine-tuning API not offering a "list all jobs"
+// endpoint.
+type FineTuningStore interface {
+	// SaveFineTuningJob upserts a fine-tuning job's tracked state.
+	SaveFineTuningJob(ctx context.Context, job FineTuningJob) error
+
+	// ListFineTuningJobs returns every tracked fine-tuning job, most
+	// recently created first.
+	ListFineTuningJobs(ctx context.Context) ([]FineTuningJob, error)
+}